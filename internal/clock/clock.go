@@ -0,0 +1,60 @@
+// Package clock abstracts away the standard "time" package's Now, NewTimer
+// and AfterFunc so that code which depends on the passage of time can be
+// driven by a deterministic mock in tests instead of sleeping on the wall
+// clock.
+package clock
+
+import "time"
+
+// Timer mirrors the parts of *time.Timer that callers need: a channel that
+// receives the fire time once the timer expires, and Stop to cancel it.
+//
+// Unlike *time.Timer, C is a method rather than a field so that mock
+// implementations can lazily create or swap the underlying channel.
+type Timer interface {
+	// C returns the channel on which the fire time is delivered.
+	C() <-chan time.Time
+
+	// Stop prevents the Timer from firing, as time.Timer.Stop does. It
+	// returns true if it stopped the timer, false if the timer has already
+	// expired or been stopped.
+	Stop() bool
+}
+
+// Clock abstracts time.Now, time.NewTimer and time.AfterFunc. Production
+// code should use Real; tests should construct a *Mock and inject it instead.
+type Clock interface {
+	// Now returns the current time, as time.Now does.
+	Now() time.Time
+
+	// NewTimer returns a Timer that fires after duration d, as
+	// time.NewTimer does.
+	NewTimer(d time.Duration) Timer
+
+	// AfterFunc runs f in its own goroutine after duration d, as
+	// time.AfterFunc does. The returned Timer can be used to stop it
+	// before it fires.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Real is the production Clock, backed by the standard "time" package.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (r realTimer) C() <-chan time.Time { return r.t.C }
+func (r realTimer) Stop() bool          { return r.t.Stop() }
@@ -0,0 +1,80 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMockAdvanceOrdersTimers(t *testing.T) {
+	m := NewMock(time.UnixMilli(0))
+	t1 := m.NewTimer(30 * time.Millisecond)
+	t2 := m.NewTimer(10 * time.Millisecond)
+	t3 := m.NewTimer(20 * time.Millisecond)
+
+	m.Advance(25 * time.Millisecond)
+
+	var fired []time.Time
+	select {
+	case ft := <-t2.C():
+		fired = append(fired, ft)
+	default:
+		t.Fatalf("t2 should have fired")
+	}
+	select {
+	case ft := <-t3.C():
+		fired = append(fired, ft)
+	default:
+		t.Fatalf("t3 should have fired")
+	}
+	select {
+	case <-t1.C():
+		t.Fatalf("t1 should not have fired yet")
+	default:
+	}
+	if !fired[0].Before(fired[1]) && !fired[0].Equal(fired[1]) {
+		t.Errorf("expected timers to fire in order, got %v", fired)
+	}
+}
+
+func TestMockStop(t *testing.T) {
+	m := NewMock(time.UnixMilli(0))
+	tm := m.NewTimer(10 * time.Millisecond)
+	if !tm.Stop() {
+		t.Errorf("expected Stop to report true")
+	}
+	if tm.Stop() {
+		t.Errorf("expected second Stop to report false")
+	}
+	m.Advance(20 * time.Millisecond)
+	select {
+	case <-tm.C():
+		t.Errorf("stopped timer should not fire")
+	default:
+	}
+}
+
+func TestMockAfterFunc(t *testing.T) {
+	m := NewMock(time.UnixMilli(0))
+	done := make(chan struct{})
+	m.AfterFunc(10*time.Millisecond, func() { close(done) })
+	m.Advance(10 * time.Millisecond)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("AfterFunc callback was not invoked")
+	}
+}
+
+func TestMockSet(t *testing.T) {
+	m := NewMock(time.UnixMilli(0))
+	tm := m.NewTimer(100 * time.Millisecond)
+	m.Set(time.UnixMilli(100))
+	select {
+	case <-tm.C():
+	default:
+		t.Fatalf("expected timer to fire after Set")
+	}
+	if !m.Now().Equal(time.UnixMilli(100)) {
+		t.Errorf("expected now to be 100ms, got %v", m.Now())
+	}
+}
@@ -0,0 +1,145 @@
+package clock
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Mock is a Clock whose notion of "now" only moves when Advance or Set is
+// called. It maintains a min-heap of pending timers ordered by their fire
+// time and fires all due timers, in order, whenever the clock is moved
+// forward.
+//
+// Mock is safe for concurrent use, including timers whose C channel is read
+// from a different goroutine than the one calling Advance/Set (e.g. a
+// background resume() loop under test).
+type Mock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers mockTimerHeap
+}
+
+// NewMock creates a Mock clock whose current time is now.
+func NewMock(now time.Time) *Mock {
+	return &Mock{now: now}
+}
+
+// Now returns the mock clock's current time.
+func (m *Mock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+// NewTimer returns a Timer that fires once the mock clock reaches or
+// passes now+d.
+func (m *Mock) NewTimer(d time.Duration) Timer {
+	return m.schedule(d, nil)
+}
+
+// AfterFunc runs f in its own goroutine once the mock clock reaches or
+// passes now+d.
+func (m *Mock) AfterFunc(d time.Duration, f func()) Timer {
+	return m.schedule(d, f)
+}
+
+func (m *Mock) schedule(d time.Duration, f func()) *mockTimer {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	t := &mockTimer{
+		owner:  m,
+		at:     m.now.Add(d),
+		c:      make(chan time.Time, 1),
+		fn:     f,
+		active: true,
+	}
+	heap.Push(&m.timers, t)
+	return t
+}
+
+// Advance moves the mock clock forward by d, firing any timers whose
+// deadline has been reached or passed, in order from earliest to latest.
+func (m *Mock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setLocked(m.now.Add(d))
+}
+
+// Set jumps the mock clock directly to t, firing any timers whose deadline
+// has been reached or passed, in order from earliest to latest.
+//
+// Set refuses to move the clock backwards; calling it with a t before the
+// current time is a no-op.
+func (m *Mock) Set(t time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if t.Before(m.now) {
+		return
+	}
+	m.setLocked(t)
+}
+
+func (m *Mock) setLocked(t time.Time) {
+	m.now = t
+	for m.timers.Len() > 0 && !m.timers[0].at.After(m.now) {
+		tm := heap.Pop(&m.timers).(*mockTimer)
+		tm.active = false
+		if tm.fn != nil {
+			go tm.fn()
+		} else {
+			tm.c <- m.now
+		}
+	}
+}
+
+func (m *Mock) stop(t *mockTimer) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !t.active {
+		return false
+	}
+	t.active = false
+	heap.Remove(&m.timers, t.index)
+	return true
+}
+
+type mockTimer struct {
+	owner  *Mock
+	at     time.Time
+	c      chan time.Time
+	fn     func()
+	active bool
+	index  int
+}
+
+func (t *mockTimer) C() <-chan time.Time { return t.c }
+func (t *mockTimer) Stop() bool          { return t.owner.stop(t) }
+
+// mockTimerHeap is a container/heap.Interface ordering pending timers by
+// their fire time, earliest first.
+type mockTimerHeap []*mockTimer
+
+func (h mockTimerHeap) Len() int           { return len(h) }
+func (h mockTimerHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h mockTimerHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *mockTimerHeap) Push(x any) {
+	t := x.(*mockTimer)
+	t.index = len(*h)
+	*h = append(*h, t)
+}
+
+func (h *mockTimerHeap) Pop() any {
+	old := *h
+	n := len(old)
+	t := old[n-1]
+	old[n-1] = nil
+	t.index = -1
+	*h = old[:n-1]
+	return t
+}
@@ -3,6 +3,7 @@
 package logu
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -13,6 +14,57 @@ import (
 // FullStackTrace, when set to true, enables the inclusion of a full stack trace in logged error messages.
 var FullStackTrace = false
 
+var logger *slog.Logger
+
+// SetLogger overrides the *slog.Logger that Do uses to emit error records.
+// It's meant for applications that already have a custom slog handler set up
+// and don't want to reconfigure slog.SetDefault globally just for logu.
+//
+// Without a call to SetLogger, logu falls back to slog.Default().
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
+func getLogger() *slog.Logger {
+	if logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// errorAttrs walks err's chain — following Unwrap() error and Unwrap() []error
+// — and returns one slog.Attr per layer, keyed "error.0", "error.1", and so
+// on in traversal order. A layer that implements
+// interface{ LogValue() slog.Value } contributes that value directly;
+// otherwise it contributes a group with its Error() string under "msg".
+func errorAttrs(err error) []slog.Attr {
+	var attrs []slog.Attr
+	i := 0
+	var walk func(e error)
+	walk = func(e error) {
+		if e == nil {
+			return
+		}
+		key := fmt.Sprintf("error.%d", i)
+		i++
+		if lv, ok := e.(interface{ LogValue() slog.Value }); ok {
+			attrs = append(attrs, slog.Attr{Key: key, Value: lv.LogValue()})
+		} else {
+			attrs = append(attrs, slog.Group(key, "msg", e.Error()))
+		}
+		switch x := e.(type) {
+		case interface{ Unwrap() []error }:
+			for _, u := range x.Unwrap() {
+				walk(u)
+			}
+		case interface{ Unwrap() error }:
+			walk(x.Unwrap())
+		}
+	}
+	walk(err)
+	return attrs
+}
+
 func getCallerLoc() (string, int) {
 	var stack [10]uintptr
 	runtime.Callers(3, stack[:])
@@ -44,6 +96,7 @@ type Context[T any] struct {
 	err      error
 	msg      string
 	exitCode int
+	attrs    []slog.Attr
 }
 
 // Context constructor for `err := ...` scenario. Don't forget to call Do()!
@@ -82,14 +135,22 @@ func (c Context[T]) Message(msg string) Context[T] {
 	return c
 }
 
+// With attaches structured fields (request ID, user, etc.) that will be logged alongside the
+// error when Do() is called. Can be chained; later calls append to earlier ones.
+func (c Context[T]) With(attrs ...slog.Attr) Context[T] {
+	c.attrs = append(append([]slog.Attr(nil), c.attrs...), attrs...)
+	return c
+}
+
 // Check the error and log the message if error is not nil.
 func (c Context[T]) Do() T {
 	if c.err != nil {
+		attrs := append([]slog.Attr(nil), c.attrs...)
+		attrs = append(attrs, errorAttrs(c.err)...)
 		if FullStackTrace {
-			slog.Error(c.getMessage(), "error", c.err, "stacktrace", getStackTrace())
-		} else {
-			slog.Error(c.getMessage(), "error", c.err)
+			attrs = append(attrs, slog.Any("stacktrace", getStackTrace()))
 		}
+		getLogger().LogAttrs(context.Background(), slog.LevelError, c.getMessage(), attrs...)
 		if c.exitCode != 0 {
 			os.Exit(c.exitCode)
 		}
@@ -0,0 +1,71 @@
+package logu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+type codeError struct {
+	code int
+	msg  string
+}
+
+func (e *codeError) Error() string { return e.msg }
+
+func (e *codeError) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("code", e.code), slog.String("msg", e.msg))
+}
+
+func TestDoLogsWrappedChainAndAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { SetLogger(nil) })
+
+	inner := &codeError{code: 42, msg: "boom"}
+	outer := fmt.Errorf("wrapped: %w", inner)
+
+	One(outer).Message("something failed").With(slog.String("request_id", "abc123")).Do()
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("invalid JSON log record: %v\n%s", err, buf.String())
+	}
+
+	if rec["msg"] != "something failed" {
+		t.Errorf("expected msg %q, got %v", "something failed", rec["msg"])
+	}
+	if rec["request_id"] != "abc123" {
+		t.Errorf("expected request_id attr to propagate, got %v", rec["request_id"])
+	}
+
+	e0, ok := rec["error.0"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected error.0 group, got %v", rec["error.0"])
+	}
+	if e0["msg"] != "wrapped: boom" {
+		t.Errorf("expected error.0.msg %q, got %v", "wrapped: boom", e0["msg"])
+	}
+
+	e1, ok := rec["error.1"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected error.1 group (from LogValue), got %v", rec["error.1"])
+	}
+	if e1["msg"] != "boom" || e1["code"] != float64(42) {
+		t.Errorf("expected error.1 to be the inner codeError's LogValue, got %v", e1)
+	}
+}
+
+func TestDoNoErrorDoesNotLog(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	t.Cleanup(func() { SetLogger(nil) })
+
+	One(nil).Do()
+
+	if buf.Len() != 0 {
+		t.Errorf("expected no log output, got %q", buf.String())
+	}
+}
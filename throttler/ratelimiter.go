@@ -0,0 +1,124 @@
+package throttler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nsf/rndgo/internal/clock"
+)
+
+// Stats reports how a RateLimiter has dispositioned the values pushed to it.
+type Stats struct {
+	// Delivered is the total number of values passed to the callback so far.
+	Delivered uint64
+	// Buffered is the number of values currently waiting for a future period.
+	Buffered uint64
+	// Dropped is the total number of values handed to onOverflow so far.
+	Dropped uint64
+}
+
+// RateLimiter is a generic type that delivers values to a callback at a steady, bounded rate:
+// up to maxPerPeriod values per period. Unlike Throttler, which fires once per period and then
+// batches everything else until the next tick, RateLimiter is designed for high-throughput
+// pipelines (e.g. console/log output) that want steady flow-through with backpressure
+// signaling rather than bursty batches.
+//
+// Values pushed beyond the current period's budget are buffered for the next period by
+// default, or handed to onOverflow (if one was provided to NewRateLimited) so the caller can
+// drop them and surface the fact that throttling occurred.
+type RateLimiter[T any] struct {
+	mutex        sync.Mutex
+	buffer       []T
+	maxPerPeriod uint64
+	inPeriod     uint64
+	period       time.Duration
+	periodTimer  clock.Timer
+	callback     func(buf []T)
+	onOverflow   func(dropped []T)
+	clock        clock.Clock
+
+	delivered uint64
+	dropped   uint64
+}
+
+// NewRateLimited creates a RateLimiter that delivers at most maxPerPeriod values to cb per
+// period. If onOverflow is non-nil, values pushed beyond that budget are handed to it instead
+// of being buffered for the next period.
+func NewRateLimited[T any](maxPerPeriod uint64, period time.Duration, cb func(buf []T), onOverflow func(dropped []T)) *RateLimiter[T] {
+	return NewRateLimitedWithClock[T](clock.Real, maxPerPeriod, period, cb, onOverflow)
+}
+
+// NewRateLimitedWithClock is the clock-controllable version of NewRateLimited. It is primarily
+// useful for tests that want to drive period boundaries deterministically via a *clock.Mock
+// instead of waiting on the wall clock.
+func NewRateLimitedWithClock[T any](c clock.Clock, maxPerPeriod uint64, period time.Duration, cb func(buf []T), onOverflow func(dropped []T)) *RateLimiter[T] {
+	return &RateLimiter[T]{
+		maxPerPeriod: maxPerPeriod,
+		period:       period,
+		callback:     cb,
+		onOverflow:   onOverflow,
+		clock:        c,
+	}
+}
+
+func (r *RateLimiter[T]) resume(timer clock.Timer) {
+	<-timer.C()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.inPeriod = 0
+	if len(r.buffer) != 0 {
+		// Drain as much of the backlog as this period's budget allows.
+		n := r.maxPerPeriod
+		if uint64(len(r.buffer)) < n {
+			n = uint64(len(r.buffer))
+		}
+		batch := r.buffer[:n]
+		r.buffer = r.buffer[n:]
+		r.inPeriod = n
+		r.delivered += n
+		r.callback(batch)
+		r.periodTimer = r.clock.NewTimer(r.period)
+		go r.resume(r.periodTimer)
+	} else {
+		r.periodTimer = nil
+	}
+}
+
+// Push adds a new value for delivery.
+//   - If the current period's budget (maxPerPeriod) hasn't been used up yet, v is delivered to
+//     cb immediately, counting against the budget.
+//   - Otherwise, if onOverflow was provided, v is handed to it right away and counted as dropped.
+//   - Otherwise, v is appended to an internal buffer and delivered once a future period has
+//     budget for it.
+func (r *RateLimiter[T]) Push(v T) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.periodTimer == nil {
+		r.periodTimer = r.clock.NewTimer(r.period)
+		go r.resume(r.periodTimer)
+	}
+	if r.inPeriod < r.maxPerPeriod {
+		r.inPeriod++
+		r.delivered++
+		r.callback([]T{v})
+		return
+	}
+	if r.onOverflow != nil {
+		r.dropped++
+		r.onOverflow([]T{v})
+		return
+	}
+	r.buffer = append(r.buffer, v)
+}
+
+// Stats returns a snapshot of how many values have been delivered and dropped so far, and how
+// many are currently buffered waiting for a future period.
+func (r *RateLimiter[T]) Stats() Stats {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return Stats{
+		Delivered: r.delivered,
+		Buffered:  uint64(len(r.buffer)),
+		Dropped:   r.dropped,
+	}
+}
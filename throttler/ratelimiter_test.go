@@ -0,0 +1,69 @@
+package throttler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nsf/rndgo/internal/clock"
+)
+
+// waitForStats polls rl.Stats() until cond is satisfied or a short deadline passes. It's needed
+// because period ticks are processed by a background goroutine, so advancing the mock clock
+// doesn't itself guarantee that goroutine has run yet.
+func waitForStats[T any](t *testing.T, rl *RateLimiter[T], cond func(Stats) bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond(rl.Stats()) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for stats condition, last stats: %+v", rl.Stats())
+}
+
+func TestRateLimiterBuffersOverflowByDefault(t *testing.T) {
+	m := clock.NewMock(time.UnixMilli(0))
+	var delivered [][]int
+	rl := NewRateLimitedWithClock[int](m, 2, time.Second, func(buf []int) {
+		delivered = append(delivered, append([]int(nil), buf...))
+	}, nil)
+
+	rl.Push(1)
+	rl.Push(2)
+	rl.Push(3) // over budget for this period, buffered
+
+	if stats := rl.Stats(); stats.Delivered != 2 || stats.Buffered != 1 || stats.Dropped != 0 {
+		t.Fatalf("unexpected stats after initial pushes: %+v", stats)
+	}
+
+	m.Advance(time.Second)
+	waitForStats(t, rl, func(s Stats) bool { return s.Buffered == 0 })
+
+	if stats := rl.Stats(); stats.Delivered != 3 || stats.Buffered != 0 || stats.Dropped != 0 {
+		t.Fatalf("unexpected stats after period tick: %+v", stats)
+	}
+	if len(delivered) != 3 || len(delivered[2]) != 1 || delivered[2][0] != 3 {
+		t.Fatalf("expected buffered value 3 delivered on next period, got %v", delivered)
+	}
+}
+
+func TestRateLimiterOverflowCallback(t *testing.T) {
+	m := clock.NewMock(time.UnixMilli(0))
+	var dropped []int
+	rl := NewRateLimitedWithClock[int](m, 1, time.Second, func(buf []int) {}, func(d []int) {
+		dropped = append(dropped, d...)
+	})
+
+	rl.Push(1)
+	rl.Push(2)
+	rl.Push(3)
+
+	stats := rl.Stats()
+	if stats.Delivered != 1 || stats.Dropped != 2 || stats.Buffered != 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	if len(dropped) != 2 || dropped[0] != 2 || dropped[1] != 3 {
+		t.Fatalf("expected [2 3] dropped, got %v", dropped)
+	}
+}
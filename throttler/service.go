@@ -0,0 +1,88 @@
+package throttler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/nsf/rndgo/internal/clock"
+)
+
+// ErrAlreadyStarted is returned by Service.Start when the service has
+// already been started.
+var ErrAlreadyStarted = errors.New("throttler: service already started")
+
+// ErrAlreadyStopped is returned by Service.Stop when the service has
+// already been stopped, and by Service.Push when the service has not been
+// started yet or has already been stopped (in both cases it isn't running).
+var ErrAlreadyStopped = errors.New("throttler: service already stopped")
+
+// Service wraps a Throttler with an explicit Start/Stop lifecycle. A bare
+// Throttler has no clean way to shut down: its background resume goroutine
+// keeps running, and leaking, for as long as the program does. Service fixes
+// that by requiring Start before Push accepts values, and by having Stop
+// cancel the pending resume timer, flush any buffered values through the
+// callback one last time, and wait for the background goroutine to exit
+// before returning.
+type Service[T any] struct {
+	throttler *Throttler[T]
+
+	mutex   sync.Mutex
+	started bool
+	stopped bool
+}
+
+// NewService creates a Service wrapping a Throttler with the given duration
+// and callback. See New for details on the throttling behavior. Call Start
+// before pushing any values.
+func NewService[T any](dur time.Duration, callback func(buf []T)) *Service[T] {
+	return NewServiceWithClock[T](clock.Real, dur, callback)
+}
+
+// NewServiceWithClock is the clock-controllable version of NewService.
+func NewServiceWithClock[T any](c clock.Clock, dur time.Duration, callback func(buf []T)) *Service[T] {
+	return &Service[T]{throttler: NewWithClock[T](c, dur, callback)}
+}
+
+// Start makes the service ready to accept values via Push. It returns
+// ErrAlreadyStarted if called more than once.
+func (s *Service[T]) Start() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.started {
+		return ErrAlreadyStarted
+	}
+	s.started = true
+	return nil
+}
+
+// Stop cancels the pending resume timer, flushes any buffered values through
+// the callback one last time, and waits for the background resume goroutine
+// to exit before returning. It returns ErrAlreadyStopped if the service was
+// never started or has already been stopped.
+func (s *Service[T]) Stop() error {
+	s.mutex.Lock()
+	if !s.started || s.stopped {
+		s.mutex.Unlock()
+		return ErrAlreadyStopped
+	}
+	s.stopped = true
+	s.mutex.Unlock()
+
+	s.throttler.stop()
+	return nil
+}
+
+// Push adds a new value to the underlying Throttler, as Throttler.Push does.
+// It returns ErrAlreadyStopped if the service has not been started, or has
+// already been stopped.
+func (s *Service[T]) Push(v T) error {
+	s.mutex.Lock()
+	running := s.started && !s.stopped
+	s.mutex.Unlock()
+	if !running {
+		return ErrAlreadyStopped
+	}
+	s.throttler.Push(v)
+	return nil
+}
@@ -0,0 +1,54 @@
+package throttler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nsf/rndgo/internal/clock"
+)
+
+func TestServiceLifecycle(t *testing.T) {
+	m := clock.NewMock(time.UnixMilli(0))
+	var batches [][]int
+	svc := NewServiceWithClock[int](m, time.Second, func(buf []int) {
+		batches = append(batches, append([]int(nil), buf...))
+	})
+
+	if err := svc.Push(1); err != ErrAlreadyStopped {
+		t.Fatalf("expected ErrAlreadyStopped before Start, got %v", err)
+	}
+
+	if err := svc.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := svc.Start(); err != ErrAlreadyStarted {
+		t.Fatalf("expected ErrAlreadyStarted on second Start, got %v", err)
+	}
+
+	if err := svc.Push(1); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if err := svc.Push(2); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	if err := svc.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if err := svc.Stop(); err != ErrAlreadyStopped {
+		t.Fatalf("expected ErrAlreadyStopped on second Stop, got %v", err)
+	}
+	if err := svc.Push(3); err != ErrAlreadyStopped {
+		t.Fatalf("expected ErrAlreadyStopped after Stop, got %v", err)
+	}
+
+	if len(batches) != 2 {
+		t.Fatalf("expected 2 batches (immediate push + flush), got %d: %v", len(batches), batches)
+	}
+	if len(batches[0]) != 1 || batches[0][0] != 1 {
+		t.Errorf("expected first batch [1], got %v", batches[0])
+	}
+	if len(batches[1]) != 1 || batches[1][0] != 2 {
+		t.Errorf("expected flushed batch [2], got %v", batches[1])
+	}
+}
@@ -7,6 +7,8 @@ package throttler
 import (
 	"sync"
 	"time"
+
+	"github.com/nsf/rndgo/internal/clock"
 )
 
 // Throttler is a generic type that limits the frequency of a callback function's execution.
@@ -18,9 +20,12 @@ import (
 type Throttler[T any] struct {
 	mutex       sync.Mutex
 	buffer      []T
-	resumeTimer *time.Timer
+	resumeTimer clock.Timer
 	duration    time.Duration
 	callback    func(buf []T)
+	clock       clock.Clock
+	wg          sync.WaitGroup // tracks the background resume goroutine, for stop()
+	quit        chan struct{}  // closed by stop() to unblock a resume() waiting on its timer
 }
 
 // New creates a new Throttler with the specified duration and callback function.
@@ -34,25 +39,65 @@ type Throttler[T any] struct {
 //	    fmt.Println("Processing:", values)
 //	})
 func New[T any](dur time.Duration, callback func(buf []T)) *Throttler[T] {
-	return &Throttler[T]{duration: dur, callback: callback}
+	return NewWithClock[T](clock.Real, dur, callback)
+}
+
+// NewWithClock is the clock-controllable version of New. It is primarily useful for tests
+// that want to drive the throttling period deterministically via a *clock.Mock instead of
+// waiting on the wall clock.
+func NewWithClock[T any](c clock.Clock, dur time.Duration, callback func(buf []T)) *Throttler[T] {
+	return &Throttler[T]{duration: dur, callback: callback, clock: c, quit: make(chan struct{})}
 }
 
-func (t *Throttler[T]) resume() {
-	<-t.resumeTimer.C
+// resume waits for timer to fire (or for stop() to request a shutdown) and
+// dispatches any buffered values. timer is passed in explicitly (rather than
+// read from t.resumeTimer) so that stop() can swap t.resumeTimer out from
+// under a running goroutine without a race.
+func (t *Throttler[T]) resume(timer clock.Timer) {
+	defer t.wg.Done()
+	select {
+	case <-timer.C():
+	case <-t.quit:
+		return
+	}
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
 	if len(t.buffer) != 0 {
 		// If there are values in the buffer, dispatch them and start a new throttling period.
 		t.callback(t.buffer)
 		t.buffer = t.buffer[:0]
-		t.resumeTimer = time.NewTimer(t.duration)
-		go t.resume()
+		t.resumeTimer = t.clock.NewTimer(t.duration)
+		t.wg.Add(1)
+		go t.resume(t.resumeTimer)
 	} else {
 		// If the buffer is empty, clear the timer.
 		t.resumeTimer = nil
 	}
 }
 
+// stop cancels the pending resume timer (if any), flushes any buffered
+// values through the callback one last time, and waits for the background
+// resume goroutine to exit. It is unexported because only Service has a
+// well-defined notion of when a Throttler should be shut down.
+func (t *Throttler[T]) stop() {
+	t.mutex.Lock()
+	if t.resumeTimer != nil {
+		t.resumeTimer.Stop()
+	}
+	t.mutex.Unlock()
+
+	close(t.quit)
+	t.wg.Wait()
+
+	t.mutex.Lock()
+	if len(t.buffer) != 0 {
+		t.callback(t.buffer)
+		t.buffer = t.buffer[:0]
+	}
+	t.resumeTimer = nil
+	t.mutex.Unlock()
+}
+
 // Push adds a new value to the Throttler for processing.
 // The behavior depends on the Throttler's state:
 //   - If no throttling period is active (i.e., no timer is running), the callback is invoked
@@ -74,8 +119,9 @@ func (t *Throttler[T]) Push(v T) {
 		t.buffer = append(t.buffer, v)
 		t.callback(t.buffer)
 		t.buffer = t.buffer[:0]
-		t.resumeTimer = time.NewTimer(t.duration)
-		go t.resume()
+		t.resumeTimer = t.clock.NewTimer(t.duration)
+		t.wg.Add(1)
+		go t.resume(t.resumeTimer)
 	} else {
 		// If in a throttling period, accumulate the value in the buffer.
 		t.buffer = append(t.buffer, v)
@@ -0,0 +1,98 @@
+package timelatch
+
+import (
+	"container/heap"
+	"time"
+)
+
+// Group owns a min-heap of *TimeLatch ordered by target time, so that a game loop or event
+// loop can manage thousands of latches with O(log n) Add/Remove and a single "what fired this
+// tick" call (PollTriggered) instead of scanning every latch by hand.
+//
+// Like the rest of this package, Group does not spawn any goroutines or timers of its own; it
+// must be polled manually.
+//
+// A Group is not safe for concurrent use without external synchronization.
+type Group struct {
+	heap groupHeap
+}
+
+// NewGroup creates an empty Group.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Len returns the number of latches currently tracked by the group.
+func (g *Group) Len() int {
+	return len(g.heap)
+}
+
+// Add starts tracking latch, ordered by its current target time (latch.Time()).
+func (g *Group) Add(latch *TimeLatch) {
+	heap.Push(&g.heap, latch)
+}
+
+// Remove stops tracking latch. It is a no-op if latch isn't currently tracked by the group.
+func (g *Group) Remove(latch *TimeLatch) {
+	if !g.owns(latch) {
+		return
+	}
+	heap.Remove(&g.heap, latch.heapIndex)
+}
+
+// Fix re-establishes the heap ordering for latch after its target time has changed via
+// SetTime, SetTimeAt, Add, AddAt, AdvanceUntilFuture or AdvanceUntilFutureAt. Call it any time
+// you mutate a latch that is currently tracked by the group; it is a no-op otherwise.
+func (g *Group) Fix(latch *TimeLatch) {
+	if !g.owns(latch) {
+		return
+	}
+	heap.Fix(&g.heap, latch.heapIndex)
+}
+
+func (g *Group) owns(latch *TimeLatch) bool {
+	i := latch.heapIndex
+	return i >= 0 && i < len(g.heap) && g.heap[i] == latch
+}
+
+// PollTriggered removes and returns every latch whose target time has been reached or passed
+// as of now, in increasing target-time (i.e. trigger) order. Latches whose target is still in
+// the future are left in the group untouched.
+func (g *Group) PollTriggered(now time.Time) []*TimeLatch {
+	var triggered []*TimeLatch
+	for len(g.heap) > 0 && !now.Before(g.heap[0].t) {
+		latch := heap.Pop(&g.heap).(*TimeLatch)
+		latch.TriggeredAt(now) // keep the latch's own edge-detection state consistent
+		triggered = append(triggered, latch)
+	}
+	return triggered
+}
+
+// groupHeap implements container/heap.Interface, ordering tracked latches by target time,
+// earliest first.
+type groupHeap []*TimeLatch
+
+func (h groupHeap) Len() int           { return len(h) }
+func (h groupHeap) Less(i, j int) bool { return h[i].t.Before(h[j].t) }
+
+func (h groupHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heapIndex = i
+	h[j].heapIndex = j
+}
+
+func (h *groupHeap) Push(x any) {
+	latch := x.(*TimeLatch)
+	latch.heapIndex = len(*h)
+	*h = append(*h, latch)
+}
+
+func (h *groupHeap) Pop() any {
+	old := *h
+	n := len(old)
+	latch := old[n-1]
+	old[n-1] = nil
+	latch.heapIndex = -1
+	*h = old[:n-1]
+	return latch
+}
@@ -0,0 +1,65 @@
+package timelatch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupPollTriggeredInOrder(t *testing.T) {
+	base := time.UnixMilli(1766990210000)
+	a := NewAt(base.Add(30*time.Millisecond), base)
+	b := NewAt(base.Add(10*time.Millisecond), base)
+	c := NewAt(base.Add(20*time.Millisecond), base)
+
+	g := NewGroup()
+	g.Add(&a)
+	g.Add(&b)
+	g.Add(&c)
+	if g.Len() != 3 {
+		t.Fatalf("expected 3 tracked latches, got %d", g.Len())
+	}
+
+	triggered := g.PollTriggered(base.Add(25 * time.Millisecond))
+	if len(triggered) != 2 || triggered[0] != &b || triggered[1] != &c {
+		t.Fatalf("expected [b c] in order, got %v", triggered)
+	}
+	if g.Len() != 1 {
+		t.Fatalf("expected 1 latch left tracked, got %d", g.Len())
+	}
+
+	triggered = g.PollTriggered(base.Add(30 * time.Millisecond))
+	if len(triggered) != 1 || triggered[0] != &a {
+		t.Fatalf("expected [a], got %v", triggered)
+	}
+	if g.Len() != 0 {
+		t.Fatalf("expected group to be empty, got %d", g.Len())
+	}
+}
+
+func TestGroupRemoveAndFix(t *testing.T) {
+	base := time.UnixMilli(1766990210000)
+	a := NewAt(base.Add(10*time.Millisecond), base)
+	b := NewAt(base.Add(20*time.Millisecond), base)
+
+	g := NewGroup()
+	g.Add(&a)
+	g.Add(&b)
+
+	g.Remove(&a)
+	if g.Len() != 1 {
+		t.Fatalf("expected 1 latch left after Remove, got %d", g.Len())
+	}
+
+	// Removing again, or removing a latch never added, must be a harmless no-op.
+	g.Remove(&a)
+	var untracked TimeLatch = NewAt(base, base)
+	g.Remove(&untracked)
+
+	b.SetTimeAt(base.Add(5*time.Millisecond), base)
+	g.Fix(&b)
+
+	triggered := g.PollTriggered(base.Add(5 * time.Millisecond))
+	if len(triggered) != 1 || triggered[0] != &b {
+		t.Fatalf("expected [b] after re-heapifying via Fix, got %v", triggered)
+	}
+}
@@ -40,6 +40,8 @@ package timelatch
 
 import (
 	"time"
+
+	"github.com/nsf/rndgo/internal/clock"
 )
 
 // TimeLatch is a simple one-shot timer trigger that tracks whether a specific
@@ -54,14 +56,28 @@ import (
 // only once, during the call where the current time first becomes >= target time.
 // Subsequent calls will return false unless the target time is changed.
 type TimeLatch struct {
-	before bool      // true if the last observed "now" was before the target time
-	t      time.Time // the target time to trigger at
+	clock     clock.Clock // source of "now" for the non-At methods
+	before    bool        // true if the last observed "now" was before the target time
+	t         time.Time   // the target time to trigger at
+	heapIndex int         // index within a Group's heap; meaningful only while tracked by a Group
 }
 
 // New creates a new TimeLatch that triggers at the given time t.
 // The comparison uses the current wall-clock time (time.Now()) as the reference.
 func New(t time.Time) TimeLatch {
-	return NewAt(t, time.Now())
+	return NewWithClock(clock.Real, t)
+}
+
+// NewWithClock is the clock-controllable version of New. It is primarily useful for tests
+// that want to drive Triggered()/AdvanceUntilFuture() deterministically via a *clock.Mock
+// instead of waiting on the wall clock.
+func NewWithClock(c clock.Clock, t time.Time) TimeLatch {
+	return TimeLatch{
+		clock:     c,
+		before:    c.Now().Before(t),
+		t:         t,
+		heapIndex: -1,
+	}
 }
 
 // NewAt creates a new TimeLatch that triggers at the given time t,
@@ -71,17 +87,20 @@ func New(t time.Time) TimeLatch {
 // This is useful for testing or when the current time is mocked.
 func NewAt(t, now time.Time) TimeLatch {
 	return TimeLatch{
-		before: now.Before(t),
-		t:      t,
+		clock:     clock.Real,
+		before:    now.Before(t),
+		t:         t,
+		heapIndex: -1,
 	}
 }
 
 // Add advances the target trigger time by the given duration d.
 //
-// It updates the internal edge-detection state based on the current wall-clock time.
+// It updates the internal edge-detection state based on the latch's clock
+// (time.Now() by default, or whatever was passed to NewWithClock).
 //
-// Returns whether the reference time (time.Now()) was before the new target time
-// at the moment of the update.
+// Returns whether the reference time was before the new target time at the
+// moment of the update.
 func (trig *TimeLatch) Add(d time.Duration) bool {
 	return trig.SetTime(trig.t.Add(d))
 }
@@ -97,13 +116,14 @@ func (trig *TimeLatch) AddAt(d time.Duration, now time.Time) bool {
 
 // SetTime changes the target trigger time to t.
 //
-// The internal state is updated based on the current wall-clock time (time.Now()).
+// The internal state is updated based on the latch's clock (time.Now() by
+// default, or whatever was passed to NewWithClock).
 //
-// Returns whether the reference time (time.Now()) was before the new target time
-// at the moment of the update. This can be useful for determining whether the
+// Returns whether the reference time was before the new target time at the
+// moment of the update. This can be useful for determining whether the
 // change crossed the trigger boundary.
 func (trig *TimeLatch) SetTime(t time.Time) bool {
-	return trig.SetTimeAt(t, time.Now())
+	return trig.SetTimeAt(t, trig.clock.Now())
 }
 
 // SetTimeAt changes the target trigger time to t and updates the internal
@@ -134,16 +154,17 @@ func (trig *TimeLatch) TriggeredAt(now time.Time) bool {
 	return wasBefore && !trig.before
 }
 
-// Triggered checks whether the latch has just triggered as of the current
-// wall-clock time (time.Now()).
+// Triggered checks whether the latch has just triggered as of the latch's
+// clock (time.Now() by default, or whatever was passed to NewWithClock).
 //
-// It behaves the same as TriggeredAt(time.Now()).
+// It behaves the same as TriggeredAt(trig.clock.Now()).
 func (trig *TimeLatch) Triggered() bool {
-	return trig.TriggeredAt(time.Now())
+	return trig.TriggeredAt(trig.clock.Now())
 }
 
 // AdvanceUntilFuture adds multiples of dur to the target time until it is
-// once again strictly after the current wall-clock time (time.Now()).
+// once again strictly after the latch's clock (time.Now() by default, or
+// whatever was passed to NewWithClock).
 //
 // It is equivalent to:
 //
@@ -158,10 +179,10 @@ func (trig *TimeLatch) Triggered() bool {
 // one dur step, even if the current target is already in the past.
 func (trig *TimeLatch) AdvanceUntilFuture(dur time.Duration) bool {
 	if dur <= 0 {
-		return time.Now().Before(trig.t) // unchanged state
+		return trig.clock.Now().Before(trig.t) // unchanged state
 	}
 
-	now := time.Now()
+	now := trig.clock.Now()
 	for !now.Before(trig.t) {
 		trig.t = trig.t.Add(dur)
 	}